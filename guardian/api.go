@@ -0,0 +1,122 @@
+package guardian
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// CheckResult is the result of the guardian_check RPC method.
+type CheckResult struct {
+	Matched bool     `json:"matched"`
+	Sources []string `json:"sources"`
+}
+
+// Stats is the result of the guardian_stats RPC method: a snapshot of
+// Guardian's Prometheus-compatible counters (see metrics.go).
+type Stats struct {
+	ChecksTotal int64 `json:"checksTotal"`
+	ReloadTotal int64 `json:"reloadTotal"`
+	FilterSize  int64 `json:"filterSize"`
+}
+
+// API exposes Guardian's runtime status and controls over JSON-RPC under the
+// "guardian" namespace. It must only be registered on trusted, non-public
+// endpoints: see Config.RPCEnabled.
+type API struct {
+	guardian *Guardian
+}
+
+// NewAPI wraps guardian for RPC registration.
+func NewAPI(guardian *Guardian) *API {
+	return &API{guardian: guardian}
+}
+
+// Status implements guardian_status: the health of every configured source.
+func (a *API) Status() []SourceStatus {
+	return a.guardian.Status()
+}
+
+// Check implements guardian_check: whether addr appears in any configured
+// filter source, and which ones. It uses the same lowercase normalization as
+// Guardian's own address checks.
+func (a *API) Check(addr string) CheckResult {
+	lowerAddr := strings.ToLower(addr)
+
+	a.guardian.mu.Lock()
+	sources := append([]*managedSource(nil), a.guardian.sources...)
+	a.guardian.mu.Unlock()
+
+	var matched []string
+	for _, src := range sources {
+		if _, ok, err := src.match(lowerAddr); err == nil && ok {
+			matched = append(matched, src.name)
+		}
+	}
+
+	return CheckResult{Matched: len(matched) > 0, Sources: matched}
+}
+
+// Reload implements guardian_reload: forces every remote source to poll
+// immediately. File sources already reload on change via their file watcher
+// and are left untouched. It stops at the first source that fails and returns
+// that error; sources after it are not polled.
+func (a *API) Reload(ctx context.Context) error {
+	a.guardian.mu.Lock()
+	sources := append([]*managedSource(nil), a.guardian.sources...)
+	a.guardian.mu.Unlock()
+
+	for _, src := range sources {
+		if err := src.reload(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats implements guardian_stats: a snapshot of Guardian's metrics.
+func (a *API) Stats() Stats {
+	return Stats{
+		ChecksTotal: checksTotal.Count(),
+		ReloadTotal: reloadTotal.Count(),
+		FilterSize:  filterSizeGauge.Value(),
+	}
+}
+
+// APIs returns the guardian_ RPC namespace, or nil when Config.RPCEnabled is
+// false. Callers (see RegisterAPIs) should only register this on endpoints
+// that aren't exposed publicly.
+func (p *Guardian) APIs() []rpc.API {
+	if !p.rpcEnabled {
+		return nil
+	}
+
+	return []rpc.API{
+		{
+			Namespace: "guardian",
+			Service:   NewAPI(p),
+		},
+	}
+}
+
+// RegisterAPIs wires the guardian_ namespace into stack, the way eth_,
+// admin_, and the other backend namespaces are registered at node
+// construction time. It is a no-op when Guardian isn't initialized or
+// Config.RPCEnabled is false.
+//
+// node/ isn't part of this source tree, so nothing calls RegisterAPIs yet;
+// the node construction code needs a call to guardian.RegisterAPIs(stack)
+// alongside its other RegisterAPIs calls, on a stack that isn't also serving
+// public HTTP, to make the namespace reachable.
+func RegisterAPIs(stack *node.Node) {
+	g, err := GetInstance()
+	if err != nil {
+		return
+	}
+
+	if apis := g.APIs(); apis != nil {
+		stack.RegisterAPIs(apis)
+	}
+}
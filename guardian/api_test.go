@@ -0,0 +1,75 @@
+package guardian
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cipherowl-ai/addressdb/address"
+	"github.com/cipherowl-ai/addressdb/store"
+)
+
+func TestAPI_CheckAndStatus(t *testing.T) {
+	filteredAddr := "0x97DCA899a2278d010d678d64fBC7C718eD5D4939"
+
+	bf, err := store.NewBloomFilterStore(&address.EVMAddressHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bf.AddAddress(filteredAddr); err != nil {
+		t.Fatal(err)
+	}
+	filterFilePath := saveBloomFilterToFile(t, bf)
+	defer os.Remove(filterFilePath)
+
+	InitInstance(Config{
+		Sources:    []SourceConfig{{Name: "local", Type: SourceTypeFile, FilterFilePath: filterFilePath}},
+		RPCEnabled: true,
+	})
+	g, err := GetInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.Reset()
+
+	apis := g.APIs()
+	if len(apis) != 1 || apis[0].Namespace != "guardian" {
+		t.Fatalf("APIs() = %+v, want a single guardian namespace", apis)
+	}
+
+	api := NewAPI(g)
+
+	// Mixed-case input must still match, same as checkAddress's normalization.
+	result := api.Check("0X97DCA899A2278D010D678D64FBC7C718ED5D4939")
+	if !result.Matched || len(result.Sources) != 1 || result.Sources[0] != "local" {
+		t.Errorf("Check() = %+v, want matched by source \"local\"", result)
+	}
+
+	if result := api.Check("0x0000000000000000000000000000000000dEaD"); result.Matched {
+		t.Errorf("Check() = %+v, want no match", result)
+	}
+
+	statuses := api.Status()
+	if len(statuses) != 1 || statuses[0].Name != "local" {
+		t.Errorf("Status() = %+v, want a single \"local\" entry", statuses)
+	}
+}
+
+func TestAPI_DisabledByDefault(t *testing.T) {
+	bf, err := store.NewBloomFilterStore(&address.EVMAddressHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filterFilePath := saveBloomFilterToFile(t, bf)
+	defer os.Remove(filterFilePath)
+
+	InitInstance(Config{FilterFilePath: filterFilePath})
+	g, err := GetInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.Reset()
+
+	if apis := g.APIs(); apis != nil {
+		t.Errorf("APIs() = %+v, want nil when RPCEnabled is unset", apis)
+	}
+}
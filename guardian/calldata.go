@@ -0,0 +1,66 @@
+package guardian
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// selectorAddressOffsets maps a 4-byte function selector to the byte offsets
+// (counting the 4-byte selector prefix) of each address-typed parameter we
+// care about inspecting. Offsets always point at the start of a 32-byte word;
+// the address itself is the last 20 bytes of that word. This table is the
+// only thing that needs to change to teach the inspector about a new
+// selector - no decoding logic is selector-specific.
+var selectorAddressOffsets = map[[4]byte][]int{
+	// transfer(address,uint256)
+	{0xa9, 0x05, 0x9c, 0xbb}: {4},
+	// transferFrom(address,address,uint256)
+	{0x23, 0xb8, 0x72, 0xdd}: {4, 36},
+	// safeTransferFrom(address,address,uint256)
+	{0x42, 0x84, 0x2e, 0x0e}: {4, 36},
+	// safeTransferFrom(address,address,uint256,bytes)
+	{0xb8, 0x8d, 0x4f, 0xde}: {4, 36},
+	// swapExactTokensForTokens(uint256,uint256,address[],address,uint256)
+	{0x38, 0xed, 0x17, 0x39}: {100},
+	// swapExactTokensForETH(uint256,uint256,address[],address,uint256)
+	{0x18, 0xcb, 0xaf, 0xe5}: {100},
+	// swapTokensForExactTokens(uint256,uint256,address[],address,uint256)
+	{0x88, 0x03, 0xdb, 0xee}: {100},
+	// swapExactETHForTokens(uint256,address[],address,uint256)
+	{0x7f, 0xf3, 0x6a, 0xb5}: {68},
+	// exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))
+	{0x41, 0x4b, 0xf3, 0x89}: {100},
+	// exactInput((bytes,address,uint256,uint256,uint256))
+	{0xc0, 0x4b, 0x8d, 0x59}: {68},
+}
+
+// extractCalldataAddresses decodes data's 4-byte selector against
+// selectorAddressOffsets and returns every address parameter it carries.
+// Unknown selectors, and offsets that fall outside data, are skipped rather
+// than treated as errors.
+func extractCalldataAddresses(data []byte) []common.Address {
+	if len(data) < 4 {
+		return nil
+	}
+
+	var selector [4]byte
+	copy(selector[:], data[:4])
+
+	offsets, ok := selectorAddressOffsets[selector]
+	if !ok {
+		return nil
+	}
+
+	addrs := make([]common.Address, 0, len(offsets))
+	for _, offset := range offsets {
+		if len(data) < offset+32 {
+			continue
+		}
+		addrs = append(addrs, common.BytesToAddress(data[offset+12:offset+32]))
+	}
+
+	return addrs
+}
+
+// WETH's withdraw(uint256) selector takes no address parameter, so it is
+// intentionally absent from selectorAddressOffsets: unwrapping WETH can't by
+// itself route funds to a filtered address.
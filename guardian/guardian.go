@@ -1,28 +1,20 @@
 package guardian
 
 import (
-	"context"
 	"errors"
-	"os/user"
-	"path/filepath"
-	"runtime"
+	"fmt"
 	"strings"
 	"sync"
-	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
-
-	"github.com/cipherowl-ai/addressdb/address"
-	"github.com/cipherowl-ai/addressdb/reload"
-	"github.com/cipherowl-ai/addressdb/store"
 )
 
 const (
-	bloomFilterFilename = "bloom_filter.gob"
+	bloomFilterFilename = "bloom_filter.gob" // legacy well-known filename, used when a configured directory has no *.gob files yet
 
-	linuxPath  = "geth/guardian"
-	darwinPath = "Library/Story/geth/guardian"
+	darwinPath = "Library/Story/geth/guardian" // relative to $HOME
 )
 
 var (
@@ -32,15 +24,32 @@ var (
 
 // Guardian provides transaction filtering to prevent interactions with certain pre-defined addresses.
 type Guardian struct {
-	filter  *store.BloomFilterStore // Stores addresses that are filtered
-	manager *reload.ReloadManager   // Manages reloading of the filter data
-	mu      sync.Mutex              // Mutex to control access to the Guardian's operations
+	sources    []*managedSource // Filter sources, checked in order
+	policy     *Policy          // Drives the Action chosen for a match; nil behaves like a single ActionDrop rule
+	rpcEnabled bool             // Mirrors Config.RPCEnabled; gates APIs()
+	mu         sync.Mutex       // Mutex to control access to the Guardian's operations
 }
 
 // Config represents the configuration for initializing Guardian.
 type Config struct {
-	FilterFilePath string // File path to the bloom filter file
+	FilterFilePath string // File path to the bloom filter file; used when Sources is empty
 	Disabled       bool   // If true, the Guardian won't filter transactions
+
+	// Sources lets an operator combine the local file filter with remote,
+	// signed feeds (see SourceConfig). When empty, FilterFilePath (or the
+	// default path) is used instead: a file becomes a single SourceTypeFile
+	// source named "local", preserving prior behavior, and a directory gives
+	// one SourceTypeFile source per *.gob file in it (see defaultSourceConfigs).
+	Sources []SourceConfig
+
+	// PolicyFilePath points at a YAML/JSON Policy (see LoadPolicyFile). When
+	// empty, Guardian falls back to its original behavior: any match drops
+	// the transaction.
+	PolicyFilePath string
+
+	// RPCEnabled registers the guardian_ JSON-RPC namespace (see APIs).
+	// Off by default; must never be set for a publicly exposed HTTP endpoint.
+	RPCEnabled bool
 }
 
 // InitInstance initializes a singleton instance of the Guardian with the given configuration.
@@ -72,78 +81,131 @@ func GetInstance() (*Guardian, error) {
 
 // newGuardian creates a new Guardian instance from the provided config.
 func newGuardian(config Config) (*Guardian, error) {
-	if config.FilterFilePath == "" {
-		path, err := getDefaultPath()
+	sourceConfigs := config.Sources
+	if len(sourceConfigs) == 0 {
+		configs, err := defaultSourceConfigs(config.FilterFilePath)
 		if err != nil {
 			return nil, err
 		}
-		config.FilterFilePath = filepath.Join(path, bloomFilterFilename)
+		sourceConfigs = configs
 	}
 
-	// Create the bloom filter from file
-	filter, err := store.NewBloomFilterStoreFromFile(config.FilterFilePath, &address.EVMAddressHandler{})
-	if err != nil {
-		return nil, err
-	}
-
-	// Create file notifier for dynamic filter reload
-	notifier, err := reload.NewFileWatcherNotifier(config.FilterFilePath, 10*time.Second)
-	if err != nil {
-		return nil, err
+	sources := make([]*managedSource, 0, len(sourceConfigs))
+	for _, cfg := range sourceConfigs {
+		src, err := buildSource(cfg)
+		if err != nil {
+			for _, started := range sources {
+				started.stop()
+			}
+			return nil, fmt.Errorf("guardian: source %q: %w", cfg.Name, err)
+		}
+		sources = append(sources, src)
 	}
 
-	// Start reload manager
-	manager := reload.NewReloadManager(filter, notifier)
-	if err := manager.Start(context.Background()); err != nil {
-		return nil, err
+	var policy *Policy
+	if config.PolicyFilePath != "" {
+		var err error
+		policy, err = LoadPolicyFile(config.PolicyFilePath)
+		if err != nil {
+			for _, started := range sources {
+				started.stop()
+			}
+			return nil, fmt.Errorf("guardian: policy: %w", err)
+		}
 	}
 
-	log.Info("Guardian initialized", "file", config.FilterFilePath)
-	return &Guardian{
-		filter:  filter,
-		manager: manager,
-	}, nil
+	log.Info("Guardian initialized", "sources", len(sources), "policy", config.PolicyFilePath != "", "rpc", config.RPCEnabled)
+	return &Guardian{sources: sources, policy: policy, rpcEnabled: config.RPCEnabled}, nil
 }
 
 // CheckTransaction checks if the sender or recipient in the transaction is in the filter file.
-// Returns true if the transaction interacts with any filtered addresses.
+// Addresses decoded from the calldata are checked too (see Evaluate).
+// Returns true if the transaction's Decision is to drop it. Callers that care
+// about the matched address/tag/direction, or about non-Drop actions, should
+// call Evaluate directly.
 func (p *Guardian) CheckTransaction(signer types.Signer, tx *types.Transaction) bool {
-	// Extract the sender's address
+	return p.Evaluate(signer, tx).Action == ActionDrop
+}
+
+// Evaluate runs tx's sender, recipient, and decoded calldata addresses
+// through every configured filter source and returns the Decision the
+// policy assigns. The first non-Allow match wins; if every match resolves to
+// ActionAllow (or nothing matches), the transaction is allowed.
+func (p *Guardian) Evaluate(signer types.Signer, tx *types.Transaction) Decision {
+	checksTotal.Inc(1)
+
 	from, err := types.Sender(signer, tx)
 	if err != nil {
 		log.Error("Failed to extract 'from' address", "err", err)
-		return false
+		return Decision{Action: ActionAllow}
 	}
 
-	// Check the sender's address
-	if filtered, err := p.checkAddress(tx, from.Hex(), from.Hex()); err != nil || filtered {
-		if err != nil {
-			log.Error("Error checking sender address", "err", err)
+	if decision, blocked := p.evaluateAllowLists(tx, from); blocked {
+		return decision
+	}
+
+	var allowed *Decision
+
+	check := func(addr common.Address, direction Direction) (Decision, bool) {
+		return p.evaluateAddress(tx, from.Hex(), addr.Hex(), direction)
+	}
+
+	if decision, matched := check(from, DirectionFrom); matched {
+		if decision.Action != ActionAllow {
+			return decision
 		}
-		return filtered
+		allowed = &decision
 	}
 
-	// Check the recipient's address if applicable
 	if to := tx.To(); to != nil {
-		if filtered, err := p.checkAddress(tx, from.Hex(), to.Hex()); err != nil || filtered {
-			if err != nil {
-				log.Error("Error checking recipient address", "err", err)
+		if decision, matched := check(*to, DirectionTo); matched {
+			if decision.Action != ActionAllow {
+				return decision
+			}
+			if allowed == nil {
+				allowed = &decision
+			}
+		}
+	}
+
+	for _, addr := range extractCalldataAddresses(tx.Data()) {
+		if decision, matched := check(addr, DirectionCalldata); matched {
+			if decision.Action != ActionAllow {
+				return decision
+			}
+			if allowed == nil {
+				allowed = &decision
 			}
-			return filtered
 		}
 	}
 
-	return false
+	if allowed != nil {
+		return *allowed
+	}
+	return Decision{Action: ActionAllow}
 }
 
-// Stop shuts down Guardian, stops the filter reload manager safely.
+// Stop shuts down Guardian, stopping every configured source safely.
 func (p *Guardian) Stop() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if p.manager != nil {
-		_ = p.manager.Stop()
+	for _, src := range p.sources {
+		src.stop()
+	}
+}
+
+// Status reports the health of every configured filter source, in the order
+// they were configured.
+func (p *Guardian) Status() []SourceStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := make([]SourceStatus, 0, len(p.sources))
+	for _, src := range p.sources {
+		statuses = append(statuses, src.status())
 	}
+	return statuses
 }
 
 // Reset allows you to reset the Guardian instance.
@@ -164,36 +226,80 @@ func (p *Guardian) Reset() {
 	initOnce = sync.Once{}
 }
 
-// checkAddress checks if the given address is in the filter list.
-func (p *Guardian) checkAddress(tx *types.Transaction, from, addr string) (bool, error) {
-	ok, err := p.filter.CheckAddress(strings.ToLower(addr))
-	if err != nil {
-		return false, err
-	}
-	if ok {
-		if err := logFilteredEntry(filteredTxLog{filteredAddress: addr, from: from, transaction: tx}); err != nil {
-			log.Error("Failed to log filtered transaction", "err", err)
+// evaluateAddress checks addr against every non-allow-list filter source and,
+// on a match, resolves the configured Action via the policy.
+func (p *Guardian) evaluateAddress(tx *types.Transaction, from, addr string, direction Direction) (Decision, bool) {
+	lowerAddr := strings.ToLower(addr)
+
+	for _, src := range p.sources {
+		if src.allowList {
+			continue
+		}
+
+		tag, ok, err := src.match(lowerAddr)
+		if err != nil {
+			log.Error("Error checking address", "err", err, "direction", direction, "source", src.name)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		action := p.policy.actionFor(tag, direction)
+		if action != ActionAllow {
+			if err := logFilteredEntry(filteredTxLog{filteredAddress: addr, from: from, transaction: tx}); err != nil {
+				log.Error("Failed to log filtered transaction", "err", err)
+			}
+			log.Warn("Filtered address found in transaction", "tx", tx.Hash().Hex(), "address", addr, "source", src.name, "tag", tag, "action", action)
+			filteredCounter(string(direction)).Inc(1)
 		}
-		log.Warn("Filtered address found in transaction", "tx", tx.Hash().Hex(), "address", addr)
-		return true, nil
+		return Decision{Action: action, MatchedAddress: addr, MatchedTag: tag, Direction: direction}, true
 	}
 
-	return false, nil
+	return Decision{}, false
 }
 
-// getDefaultPath determines the default file path based on the operating system.
-func getDefaultPath() (string, error) {
-	u, err := user.Current()
-	if err != nil {
-		return "", err
+// evaluateAllowLists checks tx's sender and recipient (not calldata
+// addresses, which may legitimately name third parties an allow-listed
+// validator never dealt with directly) against any configured allow-list
+// source: if such a source is non-empty and doesn't contain an address seen
+// in that role, the transaction is dropped. An allow-list source backed by
+// an empty filter is treated as not yet populated and skipped, rather than
+// dropping every transaction.
+func (p *Guardian) evaluateAllowLists(tx *types.Transaction, from common.Address) (Decision, bool) {
+	type addrCheck struct {
+		direction Direction
+		addr      string
 	}
 
-	switch runtime.GOOS {
-	case "linux":
-		return filepath.Join(u.HomeDir, linuxPath), nil
-	case "darwin":
-		return filepath.Join(u.HomeDir, darwinPath), nil
-	default:
-		return "", errors.New("unsupported OS for guardian")
+	candidates := []addrCheck{{DirectionFrom, from.Hex()}}
+	if to := tx.To(); to != nil {
+		candidates = append(candidates, addrCheck{DirectionTo, to.Hex()})
 	}
+
+	for _, src := range p.sources {
+		if !src.allowList {
+			continue
+		}
+		if src.empty() {
+			continue
+		}
+
+		for _, c := range candidates {
+			_, ok, err := src.match(strings.ToLower(c.addr))
+			if err != nil {
+				log.Error("Error checking allow-list address", "err", err, "direction", c.direction, "source", src.name)
+				continue
+			}
+			if ok {
+				continue
+			}
+
+			log.Warn("Address not present in allow-list, dropping", "tx", tx.Hash().Hex(), "address", c.addr, "source", src.name, "direction", c.direction)
+			filteredCounter("allowlist").Inc(1)
+			return Decision{Action: ActionDrop, MatchedAddress: c.addr, MatchedTag: src.name, Direction: c.direction}, true
+		}
+	}
+
+	return Decision{}, false
 }
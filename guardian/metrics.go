@@ -0,0 +1,32 @@
+package guardian
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	checksTotal     = metrics.NewRegisteredCounter("guardian/checks_total", nil)
+	reloadTotal     = metrics.NewRegisteredCounter("guardian/reload_total", nil)
+	filterSizeGauge = metrics.NewRegisteredGauge("guardian/filter_size", nil)
+	lastReloadGauge = metrics.NewRegisteredGaugeFloat64("guardian/last_reload_seconds", nil)
+)
+
+// filteredCounter returns the guardian/filtered_total counter for reason
+// (e.g. a Direction, or "allowlist"), registering it on first use. The
+// metrics package has no notion of labels, so each reason gets its own
+// counter name.
+func filteredCounter(reason string) metrics.Counter {
+	return metrics.GetOrRegisterCounter(fmt.Sprintf("guardian/filtered_total/%s", reason), nil)
+}
+
+// recordReload updates the reload counters/gauges after a source
+// successfully (re)loads its filter data. sizeBytes is the size of the
+// underlying filter data, used as a proxy for guardian/filter_size.
+func recordReload(sizeBytes int64) {
+	reloadTotal.Inc(1)
+	filterSizeGauge.Update(sizeBytes)
+	lastReloadGauge.Update(float64(time.Now().Unix()))
+}
@@ -0,0 +1,112 @@
+package guardian
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+const (
+	storyGuardianDirEnv = "STORY_GUARDIAN_DIR" // overrides the default path on every OS
+	xdgDataHomeEnv      = "XDG_DATA_HOME"
+	localAppDataEnv     = "LOCALAPPDATA"
+
+	linuxXDGSubpath = "story/geth/guardian" // relative to $XDG_DATA_HOME, or ~/.local/share as a fallback
+	windowsSubpath  = "Story/geth/guardian" // relative to %LOCALAPPDATA%
+)
+
+// getDefaultPath determines the default directory for Guardian's filter data.
+func getDefaultPath() (string, error) {
+	return resolveDefaultPath(runtime.GOOS)
+}
+
+// resolveDefaultPath implements getDefaultPath for a given GOOS, so the
+// per-platform logic can be exercised without actually switching platforms.
+func resolveDefaultPath(goos string) (string, error) {
+	if dir := os.Getenv(storyGuardianDirEnv); dir != "" {
+		return dir, nil
+	}
+
+	switch goos {
+	case "linux":
+		if dataHome := os.Getenv(xdgDataHomeEnv); dataHome != "" {
+			return filepath.Join(dataHome, linuxXDGSubpath), nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".local/share", linuxXDGSubpath), nil
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, darwinPath), nil
+	case "windows":
+		localAppData := os.Getenv(localAppDataEnv)
+		if localAppData == "" {
+			return "", errors.New("guardian: %LOCALAPPDATA% is not set")
+		}
+		return filepath.Join(localAppData, windowsSubpath), nil
+	default:
+		return "", fmt.Errorf("guardian: unsupported OS %q", goos)
+	}
+}
+
+// defaultSourceConfigs builds the SourceConfig list used when Config.Sources
+// isn't set. An explicit filterFilePath is honored as-is unless it names a
+// directory; like the default path (see getDefaultPath), every *.gob file in
+// a directory becomes its own named source. The directory is scanned once,
+// when Guardian is created: each file found then is reloaded on change, but a
+// *.gob file added afterwards is not loaded until Guardian is created again.
+func defaultSourceConfigs(filterFilePath string) ([]SourceConfig, error) {
+	path := filterFilePath
+	usingDefaultPath := path == ""
+	if usingDefaultPath {
+		dir, err := getDefaultPath()
+		if err != nil {
+			return nil, err
+		}
+		path = dir
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		configs, err := sourceConfigsFromDir(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(configs) > 0 {
+			return configs, nil
+		}
+		// An existing but empty directory falls back to the legacy
+		// well-known filename inside it.
+		path = filepath.Join(path, bloomFilterFilename)
+	} else if usingDefaultPath {
+		// getDefaultPath names a directory that may not exist yet.
+		path = filepath.Join(path, bloomFilterFilename)
+	}
+
+	return []SourceConfig{{Name: "local", Type: SourceTypeFile, FilterFilePath: path}}, nil
+}
+
+// sourceConfigsFromDir returns one SourceConfig per *.gob file in dir, named
+// after the file (without its extension), sorted for deterministic ordering.
+func sourceConfigsFromDir(dir string) ([]SourceConfig, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.gob"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+
+	configs := make([]SourceConfig, 0, len(matches))
+	for _, m := range matches {
+		name := strings.TrimSuffix(filepath.Base(m), filepath.Ext(m))
+		configs = append(configs, SourceConfig{Name: name, Type: SourceTypeFile, FilterFilePath: m})
+	}
+	return configs, nil
+}
@@ -0,0 +1,136 @@
+package guardian
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveDefaultPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(storyGuardianDirEnv, "")
+	t.Setenv(xdgDataHomeEnv, "")
+	t.Setenv(localAppDataEnv, "")
+
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want string
+		// wantErr checks only that an error occurs, not its text.
+		wantErr bool
+	}{
+		{
+			name: "linux without XDG_DATA_HOME falls back to ~/.local/share",
+			goos: "linux",
+			want: filepath.Join(home, ".local/share", linuxXDGSubpath),
+		},
+		{
+			name: "linux honors XDG_DATA_HOME",
+			goos: "linux",
+			env:  map[string]string{xdgDataHomeEnv: filepath.Join(home, "xdg-data")},
+			want: filepath.Join(home, "xdg-data", linuxXDGSubpath),
+		},
+		{
+			name: "darwin uses the Library path",
+			goos: "darwin",
+			want: filepath.Join(home, darwinPath),
+		},
+		{
+			name: "windows uses LOCALAPPDATA",
+			goos: "windows",
+			env:  map[string]string{localAppDataEnv: filepath.Join(home, "AppData", "Local")},
+			want: filepath.Join(home, "AppData", "Local", windowsSubpath),
+		},
+		{
+			name:    "windows without LOCALAPPDATA errors",
+			goos:    "windows",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported OS errors",
+			goos:    "plan9",
+			wantErr: true,
+		},
+		{
+			name: "STORY_GUARDIAN_DIR overrides every OS",
+			goos: "darwin",
+			env:  map[string]string{storyGuardianDirEnv: filepath.Join(home, "custom")},
+			want: filepath.Join(home, "custom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(storyGuardianDirEnv, "")
+			t.Setenv(xdgDataHomeEnv, "")
+			t.Setenv(localAppDataEnv, "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got, err := resolveDefaultPath(tt.goos)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveDefaultPath(%q) = %q, want an error", tt.goos, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveDefaultPath(%q) returned error: %v", tt.goos, err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveDefaultPath(%q) = %q, want %q", tt.goos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultSourceConfigs_LoadsEveryGobInDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"ofac.gob", "lazarus.gob"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("not a real bloom filter"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Non-.gob files in the same directory must be ignored.
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignore me"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	configs, err := defaultSourceConfigs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("defaultSourceConfigs() = %+v, want 2 sources", configs)
+	}
+	if configs[0].Name != "lazarus" || configs[1].Name != "ofac" {
+		t.Errorf("defaultSourceConfigs() names = [%q, %q], want [lazarus, ofac]", configs[0].Name, configs[1].Name)
+	}
+}
+
+func TestDefaultSourceConfigs_EmptyDirectoryFallsBackToLegacyFilename(t *testing.T) {
+	dir := t.TempDir()
+
+	configs, err := defaultSourceConfigs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].FilterFilePath != filepath.Join(dir, bloomFilterFilename) {
+		t.Errorf("defaultSourceConfigs() = %+v, want a single legacy source", configs)
+	}
+}
+
+func TestDefaultSourceConfigs_ExplicitFilePathIsUnaffected(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom_filter.gob")
+
+	configs, err := defaultSourceConfigs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].FilterFilePath != path {
+		t.Errorf("defaultSourceConfigs(%q) = %+v, want a single source pointing at the given path", path, configs)
+	}
+}
@@ -0,0 +1,96 @@
+package guardian
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Direction identifies which part of a transaction a filtered address was found in.
+type Direction string
+
+const (
+	DirectionFrom     Direction = "from"     // the transaction's sender
+	DirectionTo       Direction = "to"       // the transaction's top-level recipient
+	DirectionCalldata Direction = "calldata" // an address decoded from calldata, see extractCalldataAddresses
+)
+
+// Action is the policy response to a filtered-address match.
+type Action string
+
+const (
+	ActionDrop                Action = "drop"                 // reject the transaction
+	ActionWarn                Action = "warn"                 // log and let the transaction through
+	ActionRequireConfirmation Action = "require_confirmation" // hold for out-of-band approval
+	ActionAllow               Action = "allow"                // explicitly permitted, overriding a match
+)
+
+// Decision is the result of evaluating a transaction against Guardian's
+// configured filter sources and policy.
+type Decision struct {
+	Action         Action
+	MatchedAddress string
+	MatchedTag     string
+	Direction      Direction
+}
+
+// PolicyRule maps a filter source's tag and the direction an address was
+// found in to an Action. An empty Direction matches every direction.
+type PolicyRule struct {
+	Tag       string    `yaml:"tag" json:"tag"`
+	Direction Direction `yaml:"direction,omitempty" json:"direction,omitempty"`
+	Action    Action    `yaml:"action" json:"action"`
+}
+
+// Policy is Guardian's declarative rule set, loaded from YAML or JSON via
+// LoadPolicyFile. A nil or zero-value Policy reproduces Guardian's original
+// behavior: any match drops the transaction.
+type Policy struct {
+	// DefaultAction applies to a match that no Rule covers. Defaults to
+	// ActionDrop.
+	DefaultAction Action       `yaml:"defaultAction,omitempty" json:"defaultAction,omitempty"`
+	Rules         []PolicyRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// actionFor returns the Action configured for a match on tag in direction.
+func (p *Policy) actionFor(tag string, direction Direction) Action {
+	if p != nil {
+		for _, rule := range p.Rules {
+			if rule.Tag == tag && (rule.Direction == "" || rule.Direction == direction) {
+				return rule.Action
+			}
+		}
+		if p.DefaultAction != "" {
+			return p.DefaultAction
+		}
+	}
+	return ActionDrop
+}
+
+// LoadPolicyFile reads a Policy from path, detecting YAML vs JSON from its extension.
+func LoadPolicyFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy Policy
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &policy); err != nil {
+			return nil, fmt.Errorf("parse policy yaml: %w", err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &policy); err != nil {
+			return nil, fmt.Errorf("parse policy json: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported policy file extension %q", ext)
+	}
+
+	return &policy, nil
+}
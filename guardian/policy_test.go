@@ -0,0 +1,203 @@
+package guardian
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cipherowl-ai/addressdb/address"
+	"github.com/cipherowl-ai/addressdb/store"
+)
+
+func TestGuardian_Evaluate_PolicyWarnInsteadOfDrop(t *testing.T) {
+	filteredTo := common.HexToAddress("0x97DCA899a2278d010d678d64fBC7C718eD5D4939")
+
+	bf, err := store.NewBloomFilterStore(&address.EVMAddressHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bf.AddAddress(filteredTo.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	filterFilePath := saveBloomFilterToFile(t, bf)
+	defer os.Remove(filterFilePath)
+
+	InitInstance(Config{
+		Sources: []SourceConfig{{Name: "local", Type: SourceTypeFile, FilterFilePath: filterFilePath, Tag: "ofac"}},
+	})
+	g, err := GetInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.Reset()
+
+	// Override the policy after init so "ofac"/to matches warn rather than
+	// the default drop.
+	g.policy = &Policy{Rules: []PolicyRule{{Tag: "ofac", Direction: DirectionTo, Action: ActionWarn}}}
+
+	key, _ := crypto.GenerateKey()
+	signer := types.NewEIP155Signer(big.NewInt(18))
+	tx, err := types.SignTx(types.NewTransaction(0, filteredTo, new(big.Int), 0, new(big.Int), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decision := g.Evaluate(signer, tx)
+	if decision.Action != ActionWarn {
+		t.Errorf("Evaluate().Action = %v, want %v", decision.Action, ActionWarn)
+	}
+	if decision.MatchedTag != "ofac" || decision.Direction != DirectionTo {
+		t.Errorf("Evaluate() = %+v, want tag=ofac direction=to", decision)
+	}
+
+	// CheckTransaction only reports Drop decisions, so a warn must pass through.
+	if got := g.CheckTransaction(signer, tx); got {
+		t.Errorf("CheckTransaction() = %v, want false for a warn decision", got)
+	}
+}
+
+func TestGuardian_Evaluate_AllowList(t *testing.T) {
+	allowed := common.HexToAddress("0x97DCA899a2278d010d678d64fBC7C718eD5D4939")
+	notAllowed := common.HexToAddress("0x810205E412eB4b9f8A7faEF8faE4cF08D7c680e1")
+
+	bf, err := store.NewBloomFilterStore(&address.EVMAddressHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bf.AddAddress(allowed.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	filterFilePath := saveBloomFilterToFile(t, bf)
+	defer os.Remove(filterFilePath)
+
+	InitInstance(Config{
+		Sources: []SourceConfig{{Name: "validators", Type: SourceTypeFile, FilterFilePath: filterFilePath, AllowList: true}},
+	})
+	g, err := GetInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.Reset()
+
+	signer := types.NewEIP155Signer(big.NewInt(18))
+	key, _ := crypto.GenerateKey()
+	tx, err := types.SignTx(types.NewTransaction(0, notAllowed, new(big.Int), 0, new(big.Int), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decision := g.Evaluate(signer, tx)
+	if decision.Action != ActionDrop {
+		t.Errorf("Evaluate().Action = %v, want %v for a sender not in the allow-list", decision.Action, ActionDrop)
+	}
+}
+
+func TestGuardian_Evaluate_AllowList_EmptyFilterIsSkipped(t *testing.T) {
+	notAllowed := common.HexToAddress("0x810205E412eB4b9f8A7faEF8faE4cF08D7c680e1")
+
+	bf, err := store.NewBloomFilterStore(&address.EVMAddressHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filterFilePath := saveBloomFilterToFile(t, bf)
+	defer os.Remove(filterFilePath)
+
+	InitInstance(Config{
+		Sources: []SourceConfig{{Name: "validators", Type: SourceTypeFile, FilterFilePath: filterFilePath, AllowList: true}},
+	})
+	g, err := GetInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.Reset()
+
+	signer := types.NewEIP155Signer(big.NewInt(18))
+	key, _ := crypto.GenerateKey()
+	tx, err := types.SignTx(types.NewTransaction(0, notAllowed, new(big.Int), 0, new(big.Int), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An empty allow-list isn't populated yet; it must not drop every
+	// transaction.
+	decision := g.Evaluate(signer, tx)
+	if decision.Action != ActionAllow {
+		t.Errorf("Evaluate().Action = %v, want %v for an empty allow-list", decision.Action, ActionAllow)
+	}
+}
+
+func TestGuardian_Evaluate_AllowList_IgnoresCalldataAddresses(t *testing.T) {
+	allowedTo := common.HexToAddress("0x810205E412eB4b9f8A7faEF8faE4cF08D7c680e1")
+
+	signer := types.NewEIP155Signer(big.NewInt(18))
+	key, _ := crypto.GenerateKey()
+	// A transfer-shaped calldata names a third address never added to
+	// the allow-list; that alone must not cause a drop.
+	calldata := erc20TransferCalldata(common.HexToAddress("0x0000000000000000000000000000000000dEaD"), big.NewInt(1000))
+	tx, err := types.SignTx(types.NewTransaction(0, allowedTo, new(big.Int), 0, new(big.Int), calldata), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bf, err := store.NewBloomFilterStore(&address.EVMAddressHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range []common.Address{from, allowedTo} {
+		if err := bf.AddAddress(addr.Hex()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	filterFilePath := saveBloomFilterToFile(t, bf)
+	defer os.Remove(filterFilePath)
+
+	InitInstance(Config{
+		Sources: []SourceConfig{{Name: "validators", Type: SourceTypeFile, FilterFilePath: filterFilePath, AllowList: true}},
+	})
+	g, err := GetInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.Reset()
+
+	decision := g.Evaluate(signer, tx)
+	if decision.Action != ActionAllow {
+		t.Errorf("Evaluate().Action = %v, want %v when only a calldata address is unlisted", decision.Action, ActionAllow)
+	}
+}
+
+func TestPolicy_LoadPolicyFile(t *testing.T) {
+	tmp, err := os.CreateTemp("", "policy-*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.WriteString("defaultAction: drop\nrules:\n  - tag: ofac\n    direction: to\n    action: warn\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tmp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := LoadPolicyFile(tmp.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := policy.actionFor("ofac", DirectionTo); got != ActionWarn {
+		t.Errorf("actionFor(ofac, to) = %v, want %v", got, ActionWarn)
+	}
+	if got := policy.actionFor("ofac", DirectionFrom); got != ActionDrop {
+		t.Errorf("actionFor(ofac, from) = %v, want %v", got, ActionDrop)
+	}
+}
@@ -0,0 +1,109 @@
+package guardian
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPSourceProvider polls a manifest URL over HTTP(S), downloads each
+// referenced filter blob, and verifies it against the manifest's SHA-256
+// digest and ed25519 signature before handing it back to Guardian.
+type HTTPSourceProvider struct {
+	ManifestURL string
+	PublicKey   ed25519.PublicKey
+	Client      *http.Client
+}
+
+// NewHTTPSourceProvider builds an HTTPSourceProvider from cfg. cfg.PublicKey
+// must be a hex-encoded ed25519 public key.
+func NewHTTPSourceProvider(cfg SourceConfig) (*HTTPSourceProvider, error) {
+	pubKey, err := decodePublicKey(cfg.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTPSourceProvider{
+		ManifestURL: cfg.ManifestURL,
+		PublicKey:   pubKey,
+		Client:      http.DefaultClient,
+	}, nil
+}
+
+func decodePublicKey(hexKey string) (ed25519.PublicKey, error) {
+	pubKey, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode public key: %w", err)
+	}
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes, got %d", ed25519.PublicKeySize, len(pubKey))
+	}
+	return ed25519.PublicKey(pubKey), nil
+}
+
+// Poll fetches the manifest at p.ManifestURL, sending prevETag as
+// If-None-Match, then fetches and verifies every blob it references.
+func (p *HTTPSourceProvider) Poll(ctx context.Context, prevETag string) (*ManifestFetch, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.ManifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if prevETag != "" {
+		req.Header.Set("If-None-Match", prevETag)
+	}
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch manifest: unexpected status %s", resp.Status)
+	}
+
+	var m manifest
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+
+	filters := make(map[string][]byte, len(m.Filters))
+	for category, entry := range m.Filters {
+		blob, err := p.fetchBlob(ctx, entry.URL)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %q blob: %w", category, err)
+		}
+		if err := verifyBlob(p.PublicKey, blob, entry); err != nil {
+			return nil, fmt.Errorf("verify %q blob: %w", category, err)
+		}
+		filters[category] = blob
+	}
+
+	return &ManifestFetch{ETag: resp.Header.Get("ETag"), Filters: filters}, nil
+}
+
+func (p *HTTPSourceProvider) fetchBlob(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,32 @@
+package guardian
+
+import (
+	"fmt"
+	"strings"
+)
+
+// S3SourceProvider polls a signed manifest hosted in an S3-compatible
+// bucket. It reuses HTTPSourceProvider's plain-HTTPS polling and signature
+// verification; Bucket/Region/ManifestKey are only used as a convenience for
+// building the virtual-hosted-style URL when ManifestURL isn't set directly
+// (e.g. because the operator is using a presigned URL instead).
+type S3SourceProvider struct {
+	*HTTPSourceProvider
+}
+
+// NewS3SourceProvider builds an S3SourceProvider from cfg.
+func NewS3SourceProvider(cfg SourceConfig) (*S3SourceProvider, error) {
+	if cfg.ManifestURL == "" {
+		if cfg.Bucket == "" || cfg.Region == "" || cfg.ManifestKey == "" {
+			return nil, fmt.Errorf("guardian: S3 source %q needs ManifestURL or Bucket/Region/ManifestKey", cfg.Name)
+		}
+		cfg.ManifestURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", cfg.Bucket, cfg.Region, strings.TrimPrefix(cfg.ManifestKey, "/"))
+	}
+
+	httpProvider, err := NewHTTPSourceProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3SourceProvider{HTTPSourceProvider: httpProvider}, nil
+}
@@ -0,0 +1,188 @@
+package guardian
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cipherowl-ai/addressdb/address"
+	"github.com/cipherowl-ai/addressdb/store"
+)
+
+func newSignedBlob(t *testing.T, priv ed25519.PrivateKey, addrs ...string) []byte {
+	bf, err := store.NewBloomFilterStore(&address.EVMAddressHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range addrs {
+		if err := bf.AddAddress(addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := saveBloomFilterToFile(t, bf)
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestHTTPSourceProvider_Poll(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob := newSignedBlob(t, priv, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	sum := sha256.Sum256(blob)
+	sig := ed25519.Sign(priv, blob)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/blob.gob", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(blob)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", "\"v1\"")
+		_ = json.NewEncoder(w).Encode(manifest{
+			Filters: map[string]manifestEntry{
+				"ofac": {
+					URL:       srv.URL + "/blob.gob",
+					SHA256:    hex.EncodeToString(sum[:]),
+					Signature: base64.StdEncoding.EncodeToString(sig),
+				},
+			},
+		})
+	})
+
+	provider, err := NewHTTPSourceProvider(SourceConfig{
+		ManifestURL: srv.URL + "/manifest.json",
+		PublicKey:   hex.EncodeToString(pub),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetch, err := provider.Poll(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetch.ETag != `"v1"` {
+		t.Errorf("ETag = %q, want %q", fetch.ETag, `"v1"`)
+	}
+	if len(fetch.Filters["ofac"]) == 0 {
+		t.Fatal("expected an \"ofac\" filter blob")
+	}
+}
+
+func TestHTTPSourceProvider_Poll_RejectsBadSignature(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob := newSignedBlob(t, priv, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	sum := sha256.Sum256(blob)
+	sig := ed25519.Sign(priv, blob)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/blob.gob", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(blob)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(manifest{
+			Filters: map[string]manifestEntry{
+				"ofac": {
+					URL:       srv.URL + "/blob.gob",
+					SHA256:    hex.EncodeToString(sum[:]),
+					Signature: base64.StdEncoding.EncodeToString(sig),
+				},
+			},
+		})
+	})
+
+	// Verifying with the wrong public key must fail closed.
+	provider, err := NewHTTPSourceProvider(SourceConfig{
+		ManifestURL: srv.URL + "/manifest.json",
+		PublicKey:   hex.EncodeToString(otherPub),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := provider.Poll(context.Background(), ""); err == nil {
+		t.Fatal("expected signature verification to fail")
+	}
+}
+
+// fakeProvider is a SourceProvider whose Poll result is scripted per call,
+// for exercising managedSource.poll without an HTTP server.
+type fakeProvider struct {
+	fetches []*ManifestFetch
+	errs    []error
+	calls   int
+}
+
+func (p *fakeProvider) Poll(ctx context.Context, prevETag string) (*ManifestFetch, error) {
+	i := p.calls
+	p.calls++
+	if i < len(p.errs) && p.errs[i] != nil {
+		return nil, p.errs[i]
+	}
+	return p.fetches[i], nil
+}
+
+func TestManagedSource_Poll_EmptyManifestFailsClosed(t *testing.T) {
+	addr := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	blob := newSignedBlob(t, ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)), addr)
+
+	provider := &fakeProvider{
+		fetches: []*ManifestFetch{
+			{ETag: "v1", Filters: map[string][]byte{"ofac": blob}},
+			{ETag: "v2", Filters: map[string][]byte{}},
+		},
+	}
+
+	ctx := context.Background()
+	src, err := buildRemoteSource(SourceConfig{Name: "feed"}, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.stop()
+
+	if _, ok, err := src.match(strings.ToLower(addr)); err != nil || !ok {
+		t.Fatalf("match(%q) = %v, %v, want ok", addr, ok, err)
+	}
+
+	if err := src.poll(ctx, provider); err == nil {
+		t.Fatal("poll() with an empty manifest: want an error")
+	}
+
+	// The prior, non-empty filter set must still be in effect.
+	if _, ok, err := src.match(strings.ToLower(addr)); err != nil || !ok {
+		t.Fatalf("match(%q) after empty poll = %v, %v, want ok (prior filters retained)", addr, ok, err)
+	}
+	if status := src.status(); status.LastError == "" {
+		t.Error("status().LastError = \"\", want the empty-manifest error recorded")
+	}
+}